@@ -0,0 +1,57 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+// Package manifest defines the workload types a Copilot workspace manifest can declare.
+package manifest
+
+// Backend identifies which deployment engine is responsible for provisioning and deploying a
+// workload, so downstream deploy code can dispatch to the right one.
+type Backend string
+
+const (
+	// ECSBackend marks workloads deployed through Copilot's own ECS CloudFormation stacks.
+	ECSBackend Backend = "ecs"
+	// KubernetesBackend marks workloads deployed by applying a kustomize or Helm manifest to an
+	// EKS cluster instead.
+	KubernetesBackend Backend = "k8s"
+)
+
+// Workload type names recognized in a workload's manifest.yml "type" field, or detected from the
+// presence of a Kubernetes kustomization.yaml or Helm Chart.yaml in the workload's directory.
+const (
+	LoadBalancedWebServiceType  = "Load Balanced Web Service"
+	BackendServiceType          = "Backend Service"
+	WorkerServiceType           = "Worker Service"
+	RequestDrivenWebServiceType = "Request-Driven Web Service"
+	StaticSiteType              = "Static Site"
+	KubernetesServiceType       = "Kubernetes Service"
+	HelmServiceType             = "Helm Service"
+
+	ScheduledJobType = "Scheduled Job"
+)
+
+// ServiceTypes enumerates every workload type that can be registered as a service.
+var ServiceTypes = []string{
+	LoadBalancedWebServiceType,
+	BackendServiceType,
+	WorkerServiceType,
+	RequestDrivenWebServiceType,
+	StaticSiteType,
+	KubernetesServiceType,
+	HelmServiceType,
+}
+
+// JobTypes enumerates every workload type that can be registered as a job.
+var JobTypes = []string{
+	ScheduledJobType,
+}
+
+// BackendFor returns the deployment backend responsible for a workload of the given type.
+func BackendFor(workloadType string) Backend {
+	switch workloadType {
+	case KubernetesServiceType, HelmServiceType:
+		return KubernetesBackend
+	default:
+		return ECSBackend
+	}
+}
@@ -0,0 +1,38 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package manifest
+
+import "testing"
+
+func TestBackendFor(t *testing.T) {
+	testCases := map[string]struct {
+		workloadType string
+		wanted       Backend
+	}{
+		"Kubernetes Service dispatches to the Kubernetes backend": {
+			workloadType: KubernetesServiceType,
+			wanted:       KubernetesBackend,
+		},
+		"Helm Service dispatches to the Kubernetes backend": {
+			workloadType: HelmServiceType,
+			wanted:       KubernetesBackend,
+		},
+		"Load Balanced Web Service dispatches to the ECS backend": {
+			workloadType: LoadBalancedWebServiceType,
+			wanted:       ECSBackend,
+		},
+		"Scheduled Job dispatches to the ECS backend": {
+			workloadType: ScheduledJobType,
+			wanted:       ECSBackend,
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			if got := BackendFor(tc.workloadType); got != tc.wanted {
+				t.Errorf("BackendFor(%q) = %q, wanted %q", tc.workloadType, got, tc.wanted)
+			}
+		})
+	}
+}
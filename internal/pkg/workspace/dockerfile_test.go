@@ -0,0 +1,66 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package workspace
+
+import (
+	"testing"
+
+	"github.com/spf13/afero"
+)
+
+func TestListDockerfiles_IgnorePrecedence(t *testing.T) {
+	testCases := map[string]struct {
+		gitignore string
+		wanted    []string
+	}{
+		"excludes a directory matched by a broad pattern": {
+			gitignore: "vendor/\n",
+			wanted:    []string{"myservice/Dockerfile"},
+		},
+		"a later negation re-includes a path excluded by an earlier, broader pattern": {
+			gitignore: "vendor/*\n!vendor/myservice\n",
+			wanted:    []string{"myservice/Dockerfile", "vendor/myservice/Dockerfile"},
+		},
+		"a later broad pattern overrides an earlier negation, last match wins": {
+			gitignore: "!vendor/myservice\nvendor/*\n",
+			wanted:    []string{"myservice/Dockerfile"},
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			fs := afero.Afero{Fs: afero.NewMemMapFs()}
+			mustWriteFile(t, fs, ".gitignore", tc.gitignore)
+			mustWriteFile(t, fs, "myservice/Dockerfile", "FROM alpine\n")
+			mustWriteFile(t, fs, "vendor/myservice/Dockerfile", "FROM alpine\n")
+
+			ws := &Workspace{workingDir: ".", fsUtils: &fs}
+			infos, err := ws.ListDockerfiles(ListDockerfilesOptions{MaxDepth: 5})
+			if err != nil {
+				t.Fatalf("ListDockerfiles returned an error: %v", err)
+			}
+
+			var got []string
+			for _, info := range infos {
+				got = append(got, info.Path)
+			}
+			if len(got) != len(tc.wanted) {
+				t.Fatalf("got %v, wanted %v", got, tc.wanted)
+			}
+			for i := range got {
+				if got[i] != tc.wanted[i] {
+					t.Errorf("got %v, wanted %v", got, tc.wanted)
+					break
+				}
+			}
+		})
+	}
+}
+
+func mustWriteFile(t *testing.T, fs afero.Afero, name, content string) {
+	t.Helper()
+	if err := fs.WriteFile(name, []byte(content), 0644); err != nil {
+		t.Fatalf("write %s: %v", name, err)
+	}
+}
@@ -0,0 +1,73 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package workspace
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/spf13/afero"
+)
+
+// errReadOnlyWorkspace is returned by any mutating call against a Workspace created with
+// NewFromGit, which only ever offers read-only inspection of another team's copilot/ tree.
+var errReadOnlyWorkspace = errors.New("workspace is read-only: created with NewFromGit")
+
+// gitFS is a read-only WorkspaceFS backed by a temporary clone of a git repository. Close removes
+// the clone from disk; callers of NewFromGit must call Workspace.Close when done with it.
+type gitFS struct {
+	afero.Afero
+	dir string
+}
+
+// newGitFS clones repoURL at ref into a temporary directory and returns a WorkspaceFS rooted at the
+// checkout, for read-only inspection of another team's copilot/ tree. The clone is removed by
+// gitFS.Close, which Workspace.Close delegates to.
+func newGitFS(repoURL, ref string) (*gitFS, error) {
+	dir, err := os.MkdirTemp("", "copilot-workspace-*")
+	if err != nil {
+		return nil, fmt.Errorf("create temporary directory: %w", err)
+	}
+	if _, err := git.PlainClone(dir, false, &git.CloneOptions{
+		URL:           repoURL,
+		ReferenceName: plumbing.ReferenceName(ref),
+		SingleBranch:  true,
+		Depth:         1,
+	}); err != nil {
+		os.RemoveAll(dir)
+		return nil, fmt.Errorf("clone %s: %w", repoURL, err)
+	}
+	return &gitFS{
+		Afero: afero.Afero{Fs: afero.NewBasePathFs(afero.NewOsFs(), dir)},
+		dir:   dir,
+	}, nil
+}
+
+// WriteFile always fails: a Workspace created with NewFromGit is read-only.
+func (fs *gitFS) WriteFile(name string, data []byte, perm os.FileMode) error {
+	return errReadOnlyWorkspace
+}
+
+// Mkdir always fails: a Workspace created with NewFromGit is read-only.
+func (fs *gitFS) Mkdir(name string, perm os.FileMode) error {
+	return errReadOnlyWorkspace
+}
+
+// MkdirAll always fails: a Workspace created with NewFromGit is read-only.
+func (fs *gitFS) MkdirAll(path string, perm os.FileMode) error {
+	return errReadOnlyWorkspace
+}
+
+// Remove always fails: a Workspace created with NewFromGit is read-only.
+func (fs *gitFS) Remove(name string) error {
+	return errReadOnlyWorkspace
+}
+
+// Close removes the temporary clone backing this filesystem from disk.
+func (fs *gitFS) Close() error {
+	return os.RemoveAll(fs.dir)
+}
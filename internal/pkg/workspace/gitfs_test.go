@@ -0,0 +1,63 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package workspace
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/afero"
+)
+
+// newTestGitFS builds a gitFS over a plain temp directory, without cloning a real repository, so
+// the read-only enforcement and cleanup behavior can be tested without network access.
+func newTestGitFS(t *testing.T) *gitFS {
+	t.Helper()
+	dir, err := os.MkdirTemp("", "gitfs-test-*")
+	if err != nil {
+		t.Fatalf("create temp dir: %v", err)
+	}
+	t.Cleanup(func() { os.RemoveAll(dir) })
+	if err := os.WriteFile(filepath.Join(dir, "README.md"), []byte("hello"), 0644); err != nil {
+		t.Fatalf("seed file: %v", err)
+	}
+	return &gitFS{
+		Afero: afero.Afero{Fs: afero.NewBasePathFs(afero.NewOsFs(), dir)},
+		dir:   dir,
+	}
+}
+
+func TestGitFS_ReadOnly(t *testing.T) {
+	fs := newTestGitFS(t)
+
+	if _, err := fs.ReadFile("README.md"); err != nil {
+		t.Fatalf("ReadFile returned an error: %v", err)
+	}
+
+	if err := fs.WriteFile("new.txt", []byte("data"), 0644); err != errReadOnlyWorkspace {
+		t.Errorf("WriteFile = %v, wanted %v", err, errReadOnlyWorkspace)
+	}
+	if err := fs.Mkdir("newdir", 0755); err != errReadOnlyWorkspace {
+		t.Errorf("Mkdir = %v, wanted %v", err, errReadOnlyWorkspace)
+	}
+	if err := fs.MkdirAll("a/b/c", 0755); err != errReadOnlyWorkspace {
+		t.Errorf("MkdirAll = %v, wanted %v", err, errReadOnlyWorkspace)
+	}
+	if err := fs.Remove("README.md"); err != errReadOnlyWorkspace {
+		t.Errorf("Remove = %v, wanted %v", err, errReadOnlyWorkspace)
+	}
+}
+
+func TestGitFS_Close_RemovesClone(t *testing.T) {
+	fs := newTestGitFS(t)
+	dir := fs.dir
+
+	if err := fs.Close(); err != nil {
+		t.Fatalf("Close returned an error: %v", err)
+	}
+	if _, err := os.Stat(dir); !os.IsNotExist(err) {
+		t.Errorf("expected clone directory %s to be removed, stat error: %v", dir, err)
+	}
+}
@@ -0,0 +1,77 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package workspace
+
+import (
+	"reflect"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+)
+
+func TestMergeManifestYAML(t *testing.T) {
+	testCases := map[string]struct {
+		base    string
+		overlay string
+		wanted  string
+	}{
+		"overlay adds a new key": {
+			base: "name: my-svc\n" +
+				"type: Load Balanced Web Service\n",
+			overlay: "count: 3\n",
+			wanted: "name: my-svc\n" +
+				"type: Load Balanced Web Service\n" +
+				"count: 3\n",
+		},
+		"overlay scalar replaces base scalar": {
+			base:    "count: 1\n",
+			overlay: "count: 3\n",
+			wanted:  "count: 3\n",
+		},
+		"overlay sequence replaces base sequence, it is not appended to": {
+			base: "variables:\n" +
+				"  - A\n" +
+				"  - B\n",
+			overlay: "variables:\n" +
+				"  - C\n",
+			wanted: "variables:\n" +
+				"  - C\n",
+		},
+		"nested maps are merged key-by-key rather than replaced wholesale": {
+			base: "image:\n" +
+				"  build: Dockerfile\n" +
+				"  port: 80\n",
+			overlay: "image:\n" +
+				"  port: 8080\n",
+			wanted: "image:\n" +
+				"  build: Dockerfile\n" +
+				"  port: 8080\n",
+		},
+		"empty overlay leaves base unchanged": {
+			base:    "name: my-svc\n",
+			overlay: "",
+			wanted:  "name: my-svc\n",
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			got, err := mergeManifestYAML([]byte(tc.base), []byte(tc.overlay))
+			if err != nil {
+				t.Fatalf("mergeManifestYAML returned an error: %v", err)
+			}
+
+			var gotVal, wantedVal map[string]interface{}
+			if err := yaml.Unmarshal(got, &gotVal); err != nil {
+				t.Fatalf("unmarshal merged output: %v", err)
+			}
+			if err := yaml.Unmarshal([]byte(tc.wanted), &wantedVal); err != nil {
+				t.Fatalf("unmarshal expected output: %v", err)
+			}
+			if !reflect.DeepEqual(gotVal, wantedVal) {
+				t.Errorf("got %#v, wanted %#v", gotVal, wantedVal)
+			}
+		})
+	}
+}
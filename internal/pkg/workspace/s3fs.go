@@ -0,0 +1,125 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package workspace
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/spf13/afero"
+)
+
+// s3FS is a WorkspaceFS that keeps an in-memory mirror of a canonical copilot directory stored
+// under a prefix in an S3 bucket. The mirror is populated once on construction and every write is
+// pushed straight back to the bucket, so S3 stays the source of truth for other readers.
+type s3FS struct {
+	afero.Afero
+	bucket string
+	prefix string
+	client *s3.S3
+}
+
+// newS3FS downloads every object under prefix in bucket into an in-memory filesystem and returns a
+// WorkspaceFS backed by it.
+func newS3FS(bucket, prefix string, sess *session.Session) (*s3FS, error) {
+	fs := &s3FS{
+		Afero:  afero.Afero{Fs: afero.NewMemMapFs()},
+		bucket: bucket,
+		prefix: prefix,
+		client: s3.New(sess),
+	}
+	if err := fs.sync(); err != nil {
+		return nil, err
+	}
+	return fs, nil
+}
+
+// objectKey returns the S3 object key for a path relative to the copilot directory.
+func (fs *s3FS) objectKey(name string) string {
+	return filepath.ToSlash(filepath.Join(fs.prefix, name))
+}
+
+// sync downloads every object under the configured prefix into the in-memory mirror.
+func (fs *s3FS) sync() error {
+	var continuationToken *string
+	for {
+		out, err := fs.client.ListObjectsV2(&s3.ListObjectsV2Input{
+			Bucket:            aws.String(fs.bucket),
+			Prefix:            aws.String(fs.prefix),
+			ContinuationToken: continuationToken,
+		})
+		if err != nil {
+			return fmt.Errorf("list objects under s3://%s/%s: %w", fs.bucket, fs.prefix, err)
+		}
+		for _, obj := range out.Contents {
+			name := strings.TrimPrefix(aws.StringValue(obj.Key), fs.prefix+"/")
+			if err := fs.download(name); err != nil {
+				return err
+			}
+		}
+		if !aws.BoolValue(out.IsTruncated) {
+			return nil
+		}
+		continuationToken = out.NextContinuationToken
+	}
+}
+
+func (fs *s3FS) download(name string) error {
+	key := fs.objectKey(name)
+	out, err := fs.client.GetObject(&s3.GetObjectInput{
+		Bucket: aws.String(fs.bucket),
+		Key:    aws.String(key),
+	})
+	if err != nil {
+		return fmt.Errorf("get object %s: %w", key, err)
+	}
+	defer out.Body.Close()
+	data, err := io.ReadAll(out.Body)
+	if err != nil {
+		return fmt.Errorf("read object %s: %w", key, err)
+	}
+	if err := fs.Afero.MkdirAll(filepath.Dir(name), 0755); err != nil {
+		return err
+	}
+	return fs.Afero.WriteFile(name, data, 0644)
+}
+
+// WriteFile writes to the in-memory mirror and pushes the same content to S3, so the bucket stays
+// the canonical copy that other workspaces read from.
+func (fs *s3FS) WriteFile(name string, data []byte, perm os.FileMode) error {
+	if err := fs.Afero.WriteFile(name, data, perm); err != nil {
+		return err
+	}
+	key := fs.objectKey(name)
+	if _, err := fs.client.PutObject(&s3.PutObjectInput{
+		Bucket: aws.String(fs.bucket),
+		Key:    aws.String(key),
+		Body:   bytes.NewReader(data),
+	}); err != nil {
+		return fmt.Errorf("put object %s: %w", key, err)
+	}
+	return nil
+}
+
+// Remove deletes the file from the in-memory mirror and from S3.
+func (fs *s3FS) Remove(name string) error {
+	if err := fs.Afero.Remove(name); err != nil {
+		return err
+	}
+	key := fs.objectKey(name)
+	if _, err := fs.client.DeleteObject(&s3.DeleteObjectInput{
+		Bucket: aws.String(fs.bucket),
+		Key:    aws.String(key),
+	}); err != nil {
+		return fmt.Errorf("delete object %s: %w", key, err)
+	}
+	return nil
+}
@@ -0,0 +1,37 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package workspace
+
+import (
+	"testing"
+
+	"github.com/spf13/afero"
+)
+
+func TestS3FS_ObjectKey(t *testing.T) {
+	fs := &s3FS{prefix: "environments/prod/copilot"}
+	got := fs.objectKey("frontend/manifest.yml")
+	want := "environments/prod/copilot/frontend/manifest.yml"
+	if got != want {
+		t.Errorf("objectKey() = %q, wanted %q", got, want)
+	}
+}
+
+// TestNewFromS3_NoRedundantCopilotSegment pins down the contract documented on NewFromS3: prefix
+// itself plays the role of the copilot directory, so CopilotDirPath must resolve to the workspace
+// root rather than joining a nested "copilot/" subdirectory that doesn't exist under the prefix.
+func TestNewFromS3_NoRedundantCopilotSegment(t *testing.T) {
+	ws := &Workspace{
+		workingDir: ".",
+		copilotDir: ".",
+		fsUtils:    &afero.Afero{Fs: afero.NewMemMapFs()},
+	}
+	path, err := ws.CopilotDirPath()
+	if err != nil {
+		t.Fatalf("CopilotDirPath returned an error: %v", err)
+	}
+	if path != "." {
+		t.Errorf("CopilotDirPath() = %q, wanted %q", path, ".")
+	}
+}
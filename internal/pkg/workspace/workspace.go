@@ -15,18 +15,27 @@
 package workspace
 
 import (
+	"bytes"
+	"embed"
 	"encoding"
 	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
 	"sort"
+	"strings"
+	"text/template"
 
+	"github.com/aws/aws-sdk-go/aws/session"
 	"github.com/aws/copilot-cli/internal/pkg/manifest"
+	"github.com/go-git/go-git/v5/plumbing/format/gitignore"
 	"github.com/spf13/afero"
 	"gopkg.in/yaml.v3"
 )
 
+//go:embed addontemplates/*.yml
+var vendoredAddonTemplates embed.FS
+
 const (
 	// CopilotDirName is the name of the directory where generated infrastructure code for an application will be stored.
 	CopilotDirName = "copilot"
@@ -34,26 +43,69 @@ const (
 	SummaryFileName = ".workspace"
 
 	addonsDirName             = "addons"
+	environmentsDirName       = "environments"
 	maximumParentDirsToSearch = 5
 	pipelineFileName          = "pipeline.yml"
 	manifestFileName          = "manifest.yml"
 	buildspecFileName         = "buildspec.yml"
 
+	vendorDirName         = ".vendor"
+	vendorVersionFileName = "VERSION"
+	addonTemplatesDirName = "addontemplates"
+
+	kustomizationFileName = "kustomization.yaml"
+	helmChartFileName     = "Chart.yaml"
+
+	kubernetesServiceType = manifest.KubernetesServiceType
+	helmServiceType       = manifest.HelmServiceType
+
 	ymlFileExtension = ".yml"
 
 	dockerfileName = "Dockerfile"
 )
 
+// AppRef identifies an application hosted within a workspace's copilot directory.
+type AppRef struct {
+	Name string `yaml:"name"` // Name of the application.
+	Path string `yaml:"path"` // Path of the application's subdirectory, relative to the copilot dir. Empty for the copilot dir root.
+}
+
 // Summary is a description of what's associated with this workspace.
 type Summary struct {
-	Application string `yaml:"application"` // Name of the application.
+	Applications []AppRef `yaml:"applications"` // Applications hosted in this workspace.
+	Default      string   `yaml:"default"`      // Name of the application workspace operations default to.
+}
+
+// application looks up a registered AppRef by name.
+func (s *Summary) application(name string) (*AppRef, bool) {
+	for i := range s.Applications {
+		if s.Applications[i].Name == name {
+			return &s.Applications[i], true
+		}
+	}
+	return nil, false
+}
+
+// WorkspaceFS is the filesystem surface a Workspace needs to read and write the copilot directory.
+// A local checkout satisfies it with *afero.Afero; NewFromS3 and NewFromGit satisfy it with a
+// canonical remote backing store instead.
+type WorkspaceFS interface {
+	ReadFile(name string) ([]byte, error)
+	WriteFile(name string, data []byte, perm os.FileMode) error
+	ReadDir(dirname string) ([]os.FileInfo, error)
+	Exists(path string) (bool, error)
+	DirExists(path string) (bool, error)
+	Mkdir(name string, perm os.FileMode) error
+	MkdirAll(path string, perm os.FileMode) error
+	Remove(name string) error
+	Stat(name string) (os.FileInfo, error)
 }
 
 // Workspace typically represents a Git repository where the user has its infrastructure-as-code files as well as source files.
 type Workspace struct {
 	workingDir string
 	copilotDir string
-	fsUtils    *afero.Afero
+	fsUtils    WorkspaceFS
 }
 
 // New returns a workspace, used for reading and writing to user's local workspace.
@@ -73,35 +125,136 @@ func New() (*Workspace, error) {
 	return &ws, nil
 }
 
+// NewFromS3 returns a Workspace backed by a canonical copy of the copilot directory stored under
+// prefix in an S3 bucket, so CI systems and short-lived containers can read and write manifests
+// without cloning a repo. Objects directly under prefix (e.g. "prefix/{svc}/manifest.yml",
+// "prefix/.workspace") are the copilot directory's own contents - prefix itself plays the role of
+// the copilot dir, there's no extra nested "copilot/" keyspace to join.
+func NewFromS3(bucket, prefix string, sess *session.Session) (*Workspace, error) {
+	fsUtils, err := newS3FS(bucket, prefix, sess)
+	if err != nil {
+		return nil, fmt.Errorf("sync workspace from s3://%s/%s: %w", bucket, prefix, err)
+	}
+	return &Workspace{
+		workingDir: ".",
+		copilotDir: ".",
+		fsUtils:    fsUtils,
+	}, nil
+}
+
+// NewFromGit returns a read-only Workspace backed by a checkout of repoURL at ref, for inspecting
+// another team's copilot/ tree without a local clone. The checkout is cloned into a temporary
+// directory; callers must call Close when done with the workspace to remove it.
+func NewFromGit(repoURL, ref string) (*Workspace, error) {
+	fsUtils, err := newGitFS(repoURL, ref)
+	if err != nil {
+		return nil, fmt.Errorf("fetch workspace from %s at %s: %w", repoURL, ref, err)
+	}
+	return &Workspace{
+		workingDir: CopilotDirName,
+		copilotDir: CopilotDirName,
+		fsUtils:    fsUtils,
+	}, nil
+}
+
+// closer is implemented by WorkspaceFS backends that hold resources needing cleanup, such as the
+// temporary clone directory behind NewFromGit.
+type closer interface {
+	Close() error
+}
+
+// Close releases any resources held by the workspace's filesystem backend - for example, the
+// temporary clone directory created by NewFromGit. It's a no-op for local and S3-backed workspaces.
+func (ws *Workspace) Close() error {
+	if c, ok := ws.fsUtils.(closer); ok {
+		return c.Close()
+	}
+	return nil
+}
+
 // Create creates the copilot directory (if it doesn't already exist) in the current working directory,
-// and saves a summary with the application name.
-func (ws *Workspace) Create(appName string) error {
-	// Create an application directory, if one doesn't exist
+// and registers appName in the workspace summary, making it the default application if it's the
+// first one registered. subdir optionally roots appName at a subdirectory of the copilot dir
+// (relative to it), so that multiple applications sharing a workspace don't collide on the same
+// files; omitting it registers appName directly in the copilot dir root, same as a single-application
+// workspace.
+func (ws *Workspace) Create(appName string, subdir ...string) error {
+	// Create the copilot directory, if one doesn't exist
 	if err := ws.createCopilotDir(); err != nil {
 		return err
 	}
+	var appSubdir string
+	if len(subdir) > 0 {
+		appSubdir = subdir[0]
+	}
+	_, alreadyRegistered := ws.hasApplication(appName)
+	if err := ws.AddApplication(appName, appSubdir); err != nil {
+		return err
+	}
+	if alreadyRegistered {
+		return nil
+	}
+	// Ship the curated library of addon templates so it's discoverable from the start.
+	return ws.VendorAddons("latest", appName)
+}
 
-	// Grab an existing workspace summary, if one exists.
+// hasApplication reports whether appName is already registered in the workspace summary.
+func (ws *Workspace) hasApplication(appName string) (*AppRef, bool) {
 	summary, err := ws.Summary()
-	if err == nil {
-		// If a summary exists, but is registered to a different application, throw an error.
-		if summary.Application != appName {
-			return &errHasExistingApplication{existingAppName: summary.Application}
-		}
-		// Otherwise our work is all done.
-		return nil
+	if err != nil {
+		return nil, false
 	}
+	return summary.application(appName)
+}
 
-	// If there isn't an existing workspace summary, create it.
+// AddApplication registers an application rooted at subdir (relative to the copilot dir) in the
+// workspace, making it the default application if it's the first one registered. subdir may be
+// empty for an application that lives directly in the copilot dir. Re-registering an already known
+// application is a no-op.
+func (ws *Workspace) AddApplication(name, subdir string) error {
+	summary, err := ws.Summary()
 	var notFound *errNoAssociatedApplication
-	if errors.As(err, &notFound) {
-		return ws.writeSummary(appName)
+	switch {
+	case err == nil:
+		if _, ok := summary.application(name); ok {
+			return nil
+		}
+	case errors.As(err, &notFound):
+		summary = &Summary{}
+	default:
+		return err
+	}
+	summary.Applications = append(summary.Applications, AppRef{Name: name, Path: subdir})
+	if summary.Default == "" {
+		summary.Default = name
 	}
+	return ws.writeSummary(summary)
+}
 
-	return err
+// Applications returns the applications registered in the workspace.
+func (ws *Workspace) Applications() ([]AppRef, error) {
+	summary, err := ws.Summary()
+	if err != nil {
+		return nil, err
+	}
+	return summary.Applications, nil
 }
 
-// Summary returns a summary of the workspace - including the application name.
+// UseApplication sets the application that workspace operations default to when no app scope is
+// given to CopilotDirPath. Returns an error if name isn't already registered via AddApplication.
+func (ws *Workspace) UseApplication(name string) error {
+	summary, err := ws.Summary()
+	if err != nil {
+		return err
+	}
+	if _, ok := summary.application(name); !ok {
+		return &ErrApplicationNotFound{appName: name}
+	}
+	summary.Default = name
+	return ws.writeSummary(summary)
+}
+
+// Summary returns a summary of the workspace - including the applications registered in it.
 func (ws *Workspace) Summary() (*Summary, error) {
 	summaryPath, err := ws.summaryPath()
 	if err != nil {
@@ -119,8 +272,11 @@ func (ws *Workspace) Summary() (*Summary, error) {
 	return nil, &errNoAssociatedApplication{}
 }
 
-// ServiceNames returns the names of the services in the workspace.
-func (ws *Workspace) ServiceNames() ([]string, error) {
+// ServiceNames returns the names of the services in the workspace, including Kubernetes and Helm
+// workloads backed by a kustomization.yaml or Chart.yaml rather than a Copilot manifest.yml (both
+// are part of manifest.ServiceTypes). app optionally scopes the lookup to one application in a
+// multi-application workspace, as with CopilotDirPath.
+func (ws *Workspace) ServiceNames(app ...string) ([]string, error) {
 	return ws.workloadNames(func(wlType string) bool {
 		for _, t := range manifest.ServiceTypes {
 			if wlType == t {
@@ -128,11 +284,12 @@ func (ws *Workspace) ServiceNames() ([]string, error) {
 			}
 		}
 		return false
-	})
+	}, app...)
 }
 
-// JobNames returns the names of all jobs in the workspace.
-func (ws *Workspace) JobNames() ([]string, error) {
+// JobNames returns the names of all jobs in the workspace. app optionally scopes the lookup to one
+// application in a multi-application workspace, as with CopilotDirPath.
+func (ws *Workspace) JobNames(app ...string) ([]string, error) {
 	return ws.workloadNames(func(wlType string) bool {
 		for _, t := range manifest.JobTypes {
 			if wlType == t {
@@ -140,12 +297,12 @@ func (ws *Workspace) JobNames() ([]string, error) {
 			}
 		}
 		return false
-	})
+	}, app...)
 }
 
 // workloadNames returns the name of all workloads (either services or jobs) in the workspace.
-func (ws *Workspace) workloadNames(match func(string) bool) ([]string, error) {
-	copilotPath, err := ws.CopilotDirPath()
+func (ws *Workspace) workloadNames(match func(string) bool, app ...string) ([]string, error) {
+	copilotPath, err := ws.CopilotDirPath(app...)
 	if err != nil {
 		return nil, err
 	}
@@ -158,50 +315,89 @@ func (ws *Workspace) workloadNames(match func(string) bool) ([]string, error) {
 		if !f.IsDir() {
 			continue
 		}
-		if exists, _ := ws.fsUtils.Exists(filepath.Join(copilotPath, f.Name(), manifestFileName)); !exists {
+		wlType, ok, err := ws.detectWorkloadType(f.Name(), app...)
+		if err != nil {
+			return nil, fmt.Errorf("detect workload type for %s: %w", f.Name(), err)
+		}
+		if !ok {
 			// Swallow the error because we don't want to include any services that we don't have permissions to read.
 			continue
 		}
-		manifestBytes, err := ws.readWorkloadManifest(f.Name())
+		if match(wlType) {
+			names = append(names, f.Name())
+		}
+	}
+	return names, nil
+}
+
+// detectWorkloadType returns the workload type of a workload directory, recognizing a Copilot
+// manifest.yml, a Kubernetes kustomization.yaml, or a Helm Chart.yaml. The second return value is
+// false if the directory doesn't contain any of those files. app optionally scopes the lookup to one
+// application in a multi-application workspace, as with CopilotDirPath.
+func (ws *Workspace) detectWorkloadType(name string, app ...string) (string, bool, error) {
+	copilotPath, err := ws.CopilotDirPath(app...)
+	if err != nil {
+		return "", false, err
+	}
+	if exists, _ := ws.fsUtils.Exists(filepath.Join(copilotPath, name, manifestFileName)); exists {
+		manifestBytes, err := ws.readWorkloadManifest(name, app...)
 		if err != nil {
-			return nil, fmt.Errorf("read manifest for workload %s: %w", f.Name(), err)
+			return "", false, fmt.Errorf("read manifest for workload %s: %w", name, err)
 		}
 		wlType, err := ws.readWorkloadType(manifestBytes)
 		if err != nil {
-			return nil, err
-		}
-		if match(wlType) {
-			names = append(names, f.Name())
+			return "", false, err
 		}
+		return wlType, true, nil
 	}
-	return names, nil
+	if exists, _ := ws.fsUtils.Exists(filepath.Join(copilotPath, name, kustomizationFileName)); exists {
+		return kubernetesServiceType, true, nil
+	}
+	if exists, _ := ws.fsUtils.Exists(filepath.Join(copilotPath, name, helmChartFileName)); exists {
+		return helmServiceType, true, nil
+	}
+	return "", false, nil
 }
 
 // ReadServiceManifest returns the contents of the service's manifest under copilot/{name}/manifest.yml.
-func (ws *Workspace) ReadServiceManifest(name string) ([]byte, error) {
-	mf, err := ws.readWorkloadManifest(name)
+// app optionally scopes the read to one application in a multi-application workspace, as with
+// CopilotDirPath.
+func (ws *Workspace) ReadServiceManifest(name string, app ...string) ([]byte, error) {
+	mf, err := ws.readWorkloadManifest(name, app...)
 	if err != nil {
 		return nil, fmt.Errorf("read service %s manifest file: %w", name, err)
 	}
 	return mf, nil
 }
 
-// ReadJobManifest returns the contents of the job's manifest under copilot/{name}/manifest.yml.
-func (ws *Workspace) ReadJobManifest(name string) ([]byte, error) {
-	mf, err := ws.readWorkloadManifest(name)
+// ReadJobManifest returns the contents of the job's manifest under copilot/{name}/manifest.yml. app
+// optionally scopes the read to one application in a multi-application workspace, as with
+// CopilotDirPath.
+func (ws *Workspace) ReadJobManifest(name string, app ...string) ([]byte, error) {
+	mf, err := ws.readWorkloadManifest(name, app...)
 	if err != nil {
 		return nil, fmt.Errorf("read job %s manifest file: %w", name, err)
 	}
 	return mf, nil
 }
 
-func (ws *Workspace) readWorkloadManifest(name string) ([]byte, error) {
-	return ws.read(name, manifestFileName)
+func (ws *Workspace) readWorkloadManifest(name string, app ...string) ([]byte, error) {
+	copilotPath, err := ws.CopilotDirPath(app...)
+	if err != nil {
+		return nil, err
+	}
+	return ws.read(copilotPath, name, manifestFileName)
 }
 
-// ReadPipelineManifest returns the contents of the pipeline manifest under copilot/pipeline.yml.
-func (ws *Workspace) ReadPipelineManifest() ([]byte, error) {
-	pmPath, err := ws.pipelineManifestPath()
+// ReadPipelineManifest returns the contents of the pipeline manifest under copilot/pipeline.yml. app
+// optionally scopes the read to one application in a multi-application workspace, as with
+// CopilotDirPath.
+func (ws *Workspace) ReadPipelineManifest(app ...string) ([]byte, error) {
+	copilotPath, err := ws.CopilotDirPath(app...)
+	if err != nil {
+		return nil, err
+	}
+	pmPath, err := ws.pipelineManifestPath(app...)
 	if err != nil {
 		return nil, err
 	}
@@ -213,45 +409,101 @@ func (ws *Workspace) ReadPipelineManifest() ([]byte, error) {
 	if !manifestExists {
 		return nil, ErrNoPipelineInWorkspace
 	}
-	return ws.read(pipelineFileName)
+	return ws.read(copilotPath, pipelineFileName)
 }
 
-// WriteServiceManifest writes the service's manifest under the copilot/{name}/ directory.
-func (ws *Workspace) WriteServiceManifest(marshaler encoding.BinaryMarshaler, name string) (string, error) {
+// WriteServiceManifest writes the service's manifest under the copilot/{name}/ directory. app
+// optionally scopes the write to one application in a multi-application workspace, as with
+// CopilotDirPath.
+func (ws *Workspace) WriteServiceManifest(marshaler encoding.BinaryMarshaler, name string, app ...string) (string, error) {
 	data, err := marshaler.MarshalBinary()
 	if err != nil {
 		return "", fmt.Errorf("marshal service %s manifest to binary: %w", name, err)
 	}
-	return ws.write(data, name, manifestFileName)
+	copilotPath, err := ws.CopilotDirPath(app...)
+	if err != nil {
+		return "", err
+	}
+	return ws.write(copilotPath, data, name, manifestFileName)
 }
 
-// WriteJobManifest writes the job's manifest under the copilot/{name}/ directory.
-func (ws *Workspace) WriteJobManifest(marshaler encoding.BinaryMarshaler, name string) (string, error) {
+// WriteJobManifest writes the job's manifest under the copilot/{name}/ directory. app optionally
+// scopes the write to one application in a multi-application workspace, as with CopilotDirPath.
+func (ws *Workspace) WriteJobManifest(marshaler encoding.BinaryMarshaler, name string, app ...string) (string, error) {
 	data, err := marshaler.MarshalBinary()
 	if err != nil {
 		return "", fmt.Errorf("marshal job %s manifest to binary: %w", name, err)
 	}
-	return ws.write(data, name, manifestFileName)
+	copilotPath, err := ws.CopilotDirPath(app...)
+	if err != nil {
+		return "", err
+	}
+	return ws.write(copilotPath, data, name, manifestFileName)
+}
+
+// ReadK8sManifest returns the contents of a workload's Kubernetes manifest, preferring a
+// kustomization.yaml and falling back to a Helm Chart.yaml. app optionally scopes the read to one
+// application in a multi-application workspace, as with CopilotDirPath.
+func (ws *Workspace) ReadK8sManifest(name string, app ...string) ([]byte, error) {
+	copilotPath, err := ws.CopilotDirPath(app...)
+	if err != nil {
+		return nil, err
+	}
+	if exists, _ := ws.fsUtils.Exists(filepath.Join(copilotPath, name, kustomizationFileName)); exists {
+		return ws.read(copilotPath, name, kustomizationFileName)
+	}
+	if exists, _ := ws.fsUtils.Exists(filepath.Join(copilotPath, name, helmChartFileName)); exists {
+		return ws.read(copilotPath, name, helmChartFileName)
+	}
+	return nil, &ErrK8sManifestNotFound{workloadName: name}
+}
+
+// WriteK8sManifest writes a workload's Kubernetes manifest under copilot/{name}/{fileName}, where
+// fileName is kustomization.yaml for a kustomize-based workload or Chart.yaml for a Helm chart. app
+// optionally scopes the write to one application in a multi-application workspace, as with
+// CopilotDirPath.
+func (ws *Workspace) WriteK8sManifest(marshaler encoding.BinaryMarshaler, name, fileName string, app ...string) (string, error) {
+	data, err := marshaler.MarshalBinary()
+	if err != nil {
+		return "", fmt.Errorf("marshal k8s manifest for workload %s to binary: %w", name, err)
+	}
+	copilotPath, err := ws.CopilotDirPath(app...)
+	if err != nil {
+		return "", err
+	}
+	return ws.write(copilotPath, data, name, fileName)
 }
 
 // WritePipelineBuildspec writes the pipeline buildspec under the copilot/ directory.
 // If successful returns the full path of the file, otherwise returns an empty string and the error.
-func (ws *Workspace) WritePipelineBuildspec(marshaler encoding.BinaryMarshaler) (string, error) {
+// app optionally scopes the write to one application in a multi-application workspace, as with
+// CopilotDirPath.
+func (ws *Workspace) WritePipelineBuildspec(marshaler encoding.BinaryMarshaler, app ...string) (string, error) {
 	data, err := marshaler.MarshalBinary()
 	if err != nil {
 		return "", fmt.Errorf("marshal pipeline buildspec to binary: %w", err)
 	}
-	return ws.write(data, buildspecFileName)
+	copilotPath, err := ws.CopilotDirPath(app...)
+	if err != nil {
+		return "", err
+	}
+	return ws.write(copilotPath, data, buildspecFileName)
 }
 
 // WritePipelineManifest writes the pipeline manifest under the copilot directory.
 // If successful returns the full path of the file, otherwise returns an empty string and the error.
-func (ws *Workspace) WritePipelineManifest(marshaler encoding.BinaryMarshaler) (string, error) {
+// app optionally scopes the write to one application in a multi-application workspace, as with
+// CopilotDirPath.
+func (ws *Workspace) WritePipelineManifest(marshaler encoding.BinaryMarshaler, app ...string) (string, error) {
 	data, err := marshaler.MarshalBinary()
 	if err != nil {
 		return "", fmt.Errorf("marshal pipeline manifest to binary: %w", err)
 	}
-	return ws.write(data, pipelineFileName)
+	copilotPath, err := ws.CopilotDirPath(app...)
+	if err != nil {
+		return "", err
+	}
+	return ws.write(copilotPath, data, pipelineFileName)
 }
 
 // DeleteWorkspaceFile removes the .workspace file under copilot/ directory.
@@ -260,9 +512,10 @@ func (ws *Workspace) DeleteWorkspaceFile() error {
 	return ws.fsUtils.Remove(filepath.Join(CopilotDirName, SummaryFileName))
 }
 
-// ReadAddonsDir returns a list of file names under a service's "addons/" directory.
-func (ws *Workspace) ReadAddonsDir(svcName string) ([]string, error) {
-	copilotPath, err := ws.CopilotDirPath()
+// ReadAddonsDir returns a list of file names under a service's "addons/" directory. app optionally
+// scopes the read to one application in a multi-application workspace, as with CopilotDirPath.
+func (ws *Workspace) ReadAddonsDir(svcName string, app ...string) ([]string, error) {
+	copilotPath, err := ws.CopilotDirPath(app...)
 	if err != nil {
 		return nil, err
 	}
@@ -278,20 +531,290 @@ func (ws *Workspace) ReadAddonsDir(svcName string) ([]string, error) {
 	return names, nil
 }
 
-// ReadAddon returns the contents of a file under the service's "addons/" directory.
-func (ws *Workspace) ReadAddon(svc, fname string) ([]byte, error) {
-	return ws.read(svc, addonsDirName, fname)
+// ReadAddon returns the contents of a file under the service's "addons/" directory. app optionally
+// scopes the read to one application in a multi-application workspace, as with CopilotDirPath.
+func (ws *Workspace) ReadAddon(svc, fname string, app ...string) ([]byte, error) {
+	copilotPath, err := ws.CopilotDirPath(app...)
+	if err != nil {
+		return nil, err
+	}
+	return ws.read(copilotPath, svc, addonsDirName, fname)
 }
 
 // WriteAddon writes the content of an addon file under "{svc}/addons/{name}.yml".
-// If successful returns the full path of the file, otherwise an empty string and an error.
-func (ws *Workspace) WriteAddon(content encoding.BinaryMarshaler, svc, name string) (string, error) {
+// If successful returns the full path of the file, otherwise an empty string and an error. app
+// optionally scopes the write to one application in a multi-application workspace, as with
+// CopilotDirPath.
+func (ws *Workspace) WriteAddon(content encoding.BinaryMarshaler, svc, name string, app ...string) (string, error) {
 	data, err := content.MarshalBinary()
 	if err != nil {
 		return "", fmt.Errorf("marshal binary addon content: %w", err)
 	}
+	copilotPath, err := ws.CopilotDirPath(app...)
+	if err != nil {
+		return "", err
+	}
 	fname := name + ymlFileExtension
-	return ws.write(data, svc, addonsDirName, fname)
+	return ws.write(copilotPath, data, svc, addonsDirName, fname)
+}
+
+// ReadEnvironmentOverride returns the contents of the environment-specific manifest overlay stored
+// under copilot/{svc}/environments/{env}.yml. app optionally scopes the read to one application in a
+// multi-application workspace, as with CopilotDirPath.
+func (ws *Workspace) ReadEnvironmentOverride(svc, env string, app ...string) ([]byte, error) {
+	copilotPath, err := ws.CopilotDirPath(app...)
+	if err != nil {
+		return nil, err
+	}
+	overlay, err := ws.read(copilotPath, svc, environmentsDirName, env+ymlFileExtension)
+	if err != nil {
+		return nil, fmt.Errorf("read environment override for service %s in environment %s: %w", svc, env, err)
+	}
+	return overlay, nil
+}
+
+// WriteEnvironmentOverride writes the environment-specific manifest overlay under
+// copilot/{svc}/environments/{env}.yml. If successful returns the full path of the file, otherwise
+// returns an empty string and the error. app optionally scopes the write to one application in a
+// multi-application workspace, as with CopilotDirPath.
+func (ws *Workspace) WriteEnvironmentOverride(marshaler encoding.BinaryMarshaler, svc, env string, app ...string) (string, error) {
+	data, err := marshaler.MarshalBinary()
+	if err != nil {
+		return "", fmt.Errorf("marshal environment override for service %s in environment %s to binary: %w", svc, env, err)
+	}
+	copilotPath, err := ws.CopilotDirPath(app...)
+	if err != nil {
+		return "", err
+	}
+	return ws.write(copilotPath, data, svc, environmentsDirName, env+ymlFileExtension)
+}
+
+// ListEnvironmentOverrides returns the names of the environments that have a manifest overlay for
+// the given service, derived from the file names under copilot/{svc}/environments/. app optionally
+// scopes the read to one application in a multi-application workspace, as with CopilotDirPath.
+func (ws *Workspace) ListEnvironmentOverrides(svc string, app ...string) ([]string, error) {
+	copilotPath, err := ws.CopilotDirPath(app...)
+	if err != nil {
+		return nil, err
+	}
+	files, err := ws.fsUtils.ReadDir(filepath.Join(copilotPath, svc, environmentsDirName))
+	if err != nil {
+		return nil, fmt.Errorf("read environment overrides directory for service %s: %w", svc, err)
+	}
+	var envs []string
+	for _, f := range files {
+		if f.IsDir() || filepath.Ext(f.Name()) != ymlFileExtension {
+			continue
+		}
+		envs = append(envs, strings.TrimSuffix(f.Name(), ymlFileExtension))
+	}
+	sort.Strings(envs)
+	return envs, nil
+}
+
+// ReadMergedServiceManifest returns the service's manifest.yml deep-merged with its environment
+// override for env, if one exists. Maps are merged key-by-key; scalars and sequences present in the
+// override replace the corresponding value in the base manifest. If no override exists for env, the
+// base manifest is returned unchanged. app optionally scopes the read to one application in a
+// multi-application workspace, as with CopilotDirPath.
+func (ws *Workspace) ReadMergedServiceManifest(svc, env string, app ...string) ([]byte, error) {
+	base, err := ws.ReadServiceManifest(svc, app...)
+	if err != nil {
+		return nil, err
+	}
+	copilotPath, err := ws.CopilotDirPath(app...)
+	if err != nil {
+		return nil, err
+	}
+	overridePath := filepath.Join(copilotPath, svc, environmentsDirName, env+ymlFileExtension)
+	overrideExists, err := ws.fsUtils.Exists(overridePath)
+	if err != nil {
+		return nil, err
+	}
+	if !overrideExists {
+		return base, nil
+	}
+	overlay, err := ws.fsUtils.ReadFile(overridePath)
+	if err != nil {
+		return nil, err
+	}
+	merged, err := mergeManifestYAML(base, overlay)
+	if err != nil {
+		return nil, fmt.Errorf("merge environment override for service %s in environment %s: %w", svc, env, err)
+	}
+	return merged, nil
+}
+
+// mergeManifestYAML deep-merges an environment overlay on top of a base service manifest. Mapping
+// nodes are merged key-by-key; any other node (scalar or sequence) in the overlay replaces the
+// corresponding node in the base.
+func mergeManifestYAML(base, overlay []byte) ([]byte, error) {
+	var baseDoc, overlayDoc yaml.Node
+	if err := yaml.Unmarshal(base, &baseDoc); err != nil {
+		return nil, fmt.Errorf("unmarshal base manifest: %w", err)
+	}
+	if err := yaml.Unmarshal(overlay, &overlayDoc); err != nil {
+		return nil, fmt.Errorf("unmarshal environment override: %w", err)
+	}
+	mergeYAMLNodes(yamlDocRoot(&baseDoc), yamlDocRoot(&overlayDoc))
+	return yaml.Marshal(&baseDoc)
+}
+
+// yamlDocRoot unwraps a document node to the mapping (or scalar) node it contains.
+func yamlDocRoot(n *yaml.Node) *yaml.Node {
+	if n.Kind == yaml.DocumentNode && len(n.Content) > 0 {
+		return n.Content[0]
+	}
+	return n
+}
+
+// mergeYAMLNodes merges overlay into base in place. When both nodes are mappings, keys are merged
+// recursively; otherwise base is fully replaced by overlay.
+func mergeYAMLNodes(base, overlay *yaml.Node) {
+	if overlay == nil || overlay.Kind == 0 {
+		return
+	}
+	if base.Kind != yaml.MappingNode || overlay.Kind != yaml.MappingNode {
+		*base = *overlay
+		return
+	}
+	for i := 0; i < len(overlay.Content); i += 2 {
+		key, val := overlay.Content[i], overlay.Content[i+1]
+		if idx := yamlMappingKeyIndex(base, key.Value); idx != -1 {
+			mergeYAMLNodes(base.Content[idx+1], val)
+			continue
+		}
+		base.Content = append(base.Content, key, val)
+	}
+}
+
+// yamlMappingKeyIndex returns the index of key's node within a mapping node's Content slice, or -1
+// if the mapping has no such key.
+func yamlMappingKeyIndex(mapping *yaml.Node, key string) int {
+	for i := 0; i < len(mapping.Content); i += 2 {
+		if mapping.Content[i].Value == key {
+			return i
+		}
+	}
+	return -1
+}
+
+// VendoredAddon describes a reusable addon template available under copilot/.vendor/.
+type VendoredAddon struct {
+	Name        string // Name of the addon template, e.g. "rds".
+	Description string // Short description of what the addon provisions.
+	Path        string // Path to the template file, relative to the copilot directory.
+}
+
+// VendorAddons materializes the curated library of CloudFormation addon templates embedded in this
+// binary into copilot/.vendor/, overwriting any templates already vendored there, and records
+// version in copilot/.vendor/VERSION. The templates written are the same whatever version is. app
+// optionally scopes the write to one application in a multi-application workspace, as with
+// CopilotDirPath.
+func (ws *Workspace) VendorAddons(version string, app ...string) error {
+	copilotPath, err := ws.CopilotDirPath(app...)
+	if err != nil {
+		return err
+	}
+	vendorPath := filepath.Join(copilotPath, vendorDirName)
+	if err := ws.fsUtils.MkdirAll(vendorPath, 0755); err != nil {
+		return fmt.Errorf("create vendored addons directory: %w", err)
+	}
+	entries, err := vendoredAddonTemplates.ReadDir(addonTemplatesDirName)
+	if err != nil {
+		return fmt.Errorf("read embedded addon templates: %w", err)
+	}
+	for _, entry := range entries {
+		data, err := vendoredAddonTemplates.ReadFile(filepath.Join(addonTemplatesDirName, entry.Name()))
+		if err != nil {
+			return fmt.Errorf("read embedded addon template %s: %w", entry.Name(), err)
+		}
+		if err := ws.fsUtils.WriteFile(filepath.Join(vendorPath, entry.Name()), data, 0644); err != nil {
+			return fmt.Errorf("write vendored addon template %s: %w", entry.Name(), err)
+		}
+	}
+	return ws.fsUtils.WriteFile(filepath.Join(vendorPath, vendorVersionFileName), []byte(version), 0644)
+}
+
+// ListVendoredAddons returns the addon templates available under copilot/.vendor/, vendoring the
+// default library first if it hasn't been vendored into this workspace yet. app optionally scopes
+// the read to one application in a multi-application workspace, as with CopilotDirPath.
+func (ws *Workspace) ListVendoredAddons(app ...string) ([]VendoredAddon, error) {
+	copilotPath, err := ws.CopilotDirPath(app...)
+	if err != nil {
+		return nil, err
+	}
+	vendorPath := filepath.Join(copilotPath, vendorDirName)
+	vendored, err := ws.fsUtils.DirExists(vendorPath)
+	if err != nil {
+		return nil, err
+	}
+	if !vendored {
+		if err := ws.VendorAddons("latest", app...); err != nil {
+			return nil, fmt.Errorf("vendor default addon templates: %w", err)
+		}
+	}
+	files, err := ws.fsUtils.ReadDir(vendorPath)
+	if err != nil {
+		return nil, fmt.Errorf("read vendored addons directory: %w", err)
+	}
+	var addons []VendoredAddon
+	for _, f := range files {
+		if f.IsDir() || filepath.Ext(f.Name()) != ymlFileExtension {
+			continue
+		}
+		data, err := ws.fsUtils.ReadFile(filepath.Join(vendorPath, f.Name()))
+		if err != nil {
+			return nil, fmt.Errorf("read vendored addon template %s: %w", f.Name(), err)
+		}
+		addons = append(addons, VendoredAddon{
+			Name:        strings.TrimSuffix(f.Name(), ymlFileExtension),
+			Description: firstCommentLine(data),
+			Path:        filepath.Join(vendorDirName, f.Name()),
+		})
+	}
+	sort.Slice(addons, func(i, j int) bool { return addons[i].Name < addons[j].Name })
+	return addons, nil
+}
+
+// InstantiateAddon renders the named vendored addon template with params and writes the result
+// under copilot/{svc}/addons/{name}.yml. If successful returns the full path of the file, otherwise
+// returns an empty string and the error.
+//
+// params is supplemented with a "Name" entry set to svc, so templates can reference the service
+// they're being instantiated for without the caller having to duplicate it. Rendering fails instead
+// of silently emitting "<no value>" if the template references a param that wasn't supplied. app
+// optionally scopes the read and write to one application in a multi-application workspace, as with
+// CopilotDirPath.
+func (ws *Workspace) InstantiateAddon(name, svc string, params map[string]string, app ...string) (string, error) {
+	copilotPath, err := ws.CopilotDirPath(app...)
+	if err != nil {
+		return "", err
+	}
+	raw, err := ws.fsUtils.ReadFile(filepath.Join(copilotPath, vendorDirName, name+ymlFileExtension))
+	if err != nil {
+		return "", fmt.Errorf("read vendored addon template %s: %w", name, err)
+	}
+	tmpl, err := template.New(name).Option("missingkey=error").Parse(string(raw))
+	if err != nil {
+		return "", fmt.Errorf("parse vendored addon template %s: %w", name, err)
+	}
+	data := make(map[string]string, len(params)+1)
+	for k, v := range params {
+		data[k] = v
+	}
+	data["Name"] = svc
+	var rendered bytes.Buffer
+	if err := tmpl.Execute(&rendered, data); err != nil {
+		return "", fmt.Errorf("render vendored addon template %s (missing param?): %w", name, err)
+	}
+	return ws.write(copilotPath, rendered.Bytes(), svc, addonsDirName, name+ymlFileExtension)
+}
+
+// firstCommentLine returns the leading "# ..." comment line of a template, used as its description.
+func firstCommentLine(data []byte) string {
+	line := strings.TrimSpace(strings.SplitN(string(data), "\n", 2)[0])
+	return strings.TrimSpace(strings.TrimPrefix(line, "#"))
 }
 
 // FileStat wraps the os.Stat function.
@@ -305,17 +828,13 @@ func IsInGitRepository(fs FileStat) bool {
 	return !os.IsNotExist(err)
 }
 
-func (ws *Workspace) writeSummary(appName string) error {
+func (ws *Workspace) writeSummary(summary *Summary) error {
 	summaryPath, err := ws.summaryPath()
 	if err != nil {
 		return err
 	}
 
-	workspaceSummary := Summary{
-		Application: appName,
-	}
-
-	serializedWorkspaceSummary, err := yaml.Marshal(workspaceSummary)
+	serializedWorkspaceSummary, err := yaml.Marshal(summary)
 
 	if err != nil {
 		return err
@@ -323,8 +842,8 @@ func (ws *Workspace) writeSummary(appName string) error {
 	return ws.fsUtils.WriteFile(summaryPath, serializedWorkspaceSummary, 0644)
 }
 
-func (ws *Workspace) pipelineManifestPath() (string, error) {
-	copilotPath, err := ws.CopilotDirPath()
+func (ws *Workspace) pipelineManifestPath(app ...string) (string, error) {
+	copilotPath, err := ws.CopilotDirPath(app...)
 	if err != nil {
 		return "", err
 	}
@@ -332,8 +851,10 @@ func (ws *Workspace) pipelineManifestPath() (string, error) {
 	return pipelineManifestPath, nil
 }
 
+// summaryPath returns the path of the .workspace file, which always lives at the root of the
+// copilot dir and is shared by every application registered in the workspace.
 func (ws *Workspace) summaryPath() (string, error) {
-	copilotPath, err := ws.CopilotDirPath()
+	copilotPath, err := ws.rootCopilotDirPath()
 	if err != nil {
 		return "", err
 	}
@@ -343,15 +864,60 @@ func (ws *Workspace) summaryPath() (string, error) {
 
 func (ws *Workspace) createCopilotDir() error {
 	// First check to see if a manifest directory already exists
-	existingWorkspace, _ := ws.CopilotDirPath()
+	existingWorkspace, _ := ws.rootCopilotDirPath()
 	if existingWorkspace != "" {
 		return nil
 	}
 	return ws.fsUtils.Mkdir(CopilotDirName, 0755)
 }
 
-// CopilotDirPath returns the absolute path to the workspace's copilot dir.
-func (ws *Workspace) CopilotDirPath() (string, error) {
+// CopilotDirPath returns the path to the workspace's copilot dir: absolute for a workspace from New,
+// relative to the backing store's root for one from NewFromS3 or NewFromGit. If the workspace hosts
+// multiple applications (see AddApplication), an optional app name narrows the path down to that
+// application's subdirectory; omitting it resolves to the workspace's default application. Single-
+// application workspaces, and workspaces with no summary yet, resolve to the copilot dir root, same
+// as before multi-application support existed.
+func (ws *Workspace) CopilotDirPath(app ...string) (string, error) {
+	copilotPath, err := ws.rootCopilotDirPath()
+	if err != nil {
+		return "", err
+	}
+	subdir, err := ws.appSubdir(app...)
+	if err != nil {
+		return "", err
+	}
+	if subdir == "" {
+		return copilotPath, nil
+	}
+	return filepath.Join(copilotPath, subdir), nil
+}
+
+// appSubdir resolves the subdirectory (relative to the copilot dir root) registered for the
+// requested app scope, falling back to the workspace's default application. It resolves to the
+// root ("") if there's no workspace summary yet, or if the resolved application has no subdir.
+func (ws *Workspace) appSubdir(app ...string) (string, error) {
+	summary, err := ws.Summary()
+	var notFound *errNoAssociatedApplication
+	if errors.As(err, &notFound) {
+		return "", nil
+	}
+	if err != nil {
+		return "", err
+	}
+	name := summary.Default
+	if len(app) > 0 && app[0] != "" {
+		name = app[0]
+	}
+	ref, ok := summary.application(name)
+	if !ok {
+		return "", nil
+	}
+	return ref.Path, nil
+}
+
+// rootCopilotDirPath returns the path to the copilot dir itself (absolute or relative, as with
+// CopilotDirPath), independent of any application scoping.
+func (ws *Workspace) rootCopilotDirPath() (string, error) {
 	if ws.copilotDir != "" {
 		return ws.copilotDir, nil
 	}
@@ -392,12 +958,10 @@ func (ws *Workspace) readWorkloadType(dat []byte) (string, error) {
 	return wl.Type, nil
 }
 
-// write flushes the data to a file under the copilot directory joined by path elements.
-func (ws *Workspace) write(data []byte, elem ...string) (string, error) {
-	copilotPath, err := ws.CopilotDirPath()
-	if err != nil {
-		return "", err
-	}
+// write flushes the data to a file under copilotPath joined by the remaining path elements.
+// copilotPath is expected to come from a prior call to CopilotDirPath, scoped to whichever
+// application the caller is operating on.
+func (ws *Workspace) write(copilotPath string, data []byte, elem ...string) (string, error) {
 	pathElems := append([]string{copilotPath}, elem...)
 	filename := filepath.Join(pathElems...)
 
@@ -417,70 +981,208 @@ func (ws *Workspace) write(data []byte, elem ...string) (string, error) {
 	return filename, nil
 }
 
-// read returns the contents of the file under the copilot directory joined by path elements.
-func (ws *Workspace) read(elem ...string) ([]byte, error) {
-	copilotPath, err := ws.CopilotDirPath()
+// read returns the contents of the file under copilotPath joined by the remaining path elements.
+// copilotPath is expected to come from a prior call to CopilotDirPath, scoped to whichever
+// application the caller is operating on.
+func (ws *Workspace) read(copilotPath string, elem ...string) ([]byte, error) {
+	pathElems := append([]string{copilotPath}, elem...)
+	return ws.fsUtils.ReadFile(filepath.Join(pathElems...))
+}
+
+// ListDockerfilesOptions configures the recursive walk performed by Workspace.ListDockerfiles.
+type ListDockerfilesOptions struct {
+	MaxDepth int      // How many directory levels below the working directory to search. Defaults to 1.
+	Follow   bool     // Whether to follow symlinked directories while walking.
+	Ignore   []string // Additional gitignore-style patterns to skip, on top of .gitignore/.dockerignore.
+}
+
+// DockerfileInfo describes a Dockerfile discovered by ListDockerfiles.
+type DockerfileInfo struct {
+	Path       string   // Path to the Dockerfile, relative to the working directory.
+	Stage      string   // Name of the final build stage, if the Dockerfile names one (FROM ... AS <stage>).
+	BaseImages []string // Base images referenced by the Dockerfile's FROM instructions, in order.
+}
+
+const defaultDockerfileSearchDepth = 1
+
+var defaultIgnoredDockerfileDirs = []string{"node_modules", "vendor", ".git"}
+
+// ListDockerfiles returns information about every Dockerfile found within opts.MaxDepth directory
+// levels below the working directory (one level, by default). Paths matched by .gitignore,
+// .dockerignore, or opts.Ignore are skipped; node_modules, vendor, and .git directories are skipped
+// by default too, but a negated pattern (e.g. "!vendor/myservice") in .gitignore/.dockerignore/
+// opts.Ignore can still re-include a path under them. If no Dockerfiles are found, returns an
+// ErrDockerfileNotFound.
+func (ws *Workspace) ListDockerfiles(opts ListDockerfilesOptions) ([]DockerfileInfo, error) {
+	if opts.MaxDepth <= 0 {
+		opts.MaxDepth = defaultDockerfileSearchDepth
+	}
+	matcher, err := ws.dockerfileIgnoreMatcher(opts.Ignore)
 	if err != nil {
 		return nil, err
 	}
-	pathElems := append([]string{copilotPath}, elem...)
-	return ws.fsUtils.ReadFile(filepath.Join(pathElems...))
+	var infos []DockerfileInfo
+	visited := make(map[string]bool)
+	if err := ws.walkForDockerfiles(ws.workingDir, 0, opts, matcher, visited, &infos); err != nil {
+		return nil, err
+	}
+	if len(infos) == 0 {
+		return nil, &ErrDockerfileNotFound{dir: ws.workingDir}
+	}
+	sort.Slice(infos, func(i, j int) bool { return infos[i].Path < infos[j].Path })
+	return infos, nil
 }
 
-// ListDockerfiles returns the list of Dockerfiles within the current
-// working directory and a sub-directory level below. If an error occurs while
-// reading directories, or no Dockerfiles found returns the error.
-func (ws *Workspace) ListDockerfiles() ([]string, error) {
-	wdFiles, err := ws.fsUtils.ReadDir(ws.workingDir)
+// walkForDockerfiles recursively collects DockerfileInfo for every Dockerfile under dir, up to
+// opts.MaxDepth levels below the working directory. visited tracks the real (symlink-resolved)
+// paths of directories already walked, so a self-referential symlink can't recurse forever.
+func (ws *Workspace) walkForDockerfiles(dir string, depth int, opts ListDockerfilesOptions, matcher gitignore.Matcher, visited map[string]bool, infos *[]DockerfileInfo) error {
+	entries, err := ws.fsUtils.ReadDir(dir)
 	if err != nil {
-		return nil, fmt.Errorf("read directory: %w", err)
+		return fmt.Errorf("read directory: %w", err)
 	}
-	var directories []string
-	for _, wdFile := range wdFiles {
-		// Add current directory if a Dockerfile exists, otherwise continue.
-		if !wdFile.IsDir() {
-			if wdFile.Name() == dockerfileName {
-				directories = append(directories, filepath.Dir(wdFile.Name()))
+	for _, entry := range entries {
+		entryPath := filepath.Join(dir, entry.Name())
+		relPath, err := filepath.Rel(ws.workingDir, entryPath)
+		if err != nil {
+			return err
+		}
+		segments := strings.Split(filepath.ToSlash(relPath), "/")
+		// ReadDir describes a symlink itself rather than its target, so a symlinked directory only
+		// counts as a directory once opts.Follow is set and its target is found to be one.
+		isSymlink := entry.Mode()&os.ModeSymlink != 0
+		isDir := entry.IsDir()
+		if isSymlink && opts.Follow {
+			if target, err := ws.fsUtils.Stat(entryPath); err == nil {
+				isDir = target.IsDir()
 			}
-			continue
 		}
-
-		// Add sub-directories containing a Dockerfile one level below current directory.
-		subFiles, err := ws.fsUtils.ReadDir(wdFile.Name())
-		if err != nil {
-			return nil, fmt.Errorf("read directory: %w", err)
+		if matcher.Match(segments, isDir) {
+			continue
 		}
-		for _, f := range subFiles {
-			// NOTE: ignore directories in sub-directories.
-			if f.IsDir() {
+		if isDir {
+			if depth >= opts.MaxDepth {
 				continue
 			}
-
-			if f.Name() == dockerfileName {
-				directories = append(directories, wdFile.Name())
+			if isSymlink {
+				if !opts.Follow {
+					continue
+				}
+				real, err := filepath.EvalSymlinks(entryPath)
+				if err != nil {
+					return fmt.Errorf("resolve symlink %s: %w", entryPath, err)
+				}
+				if visited[real] {
+					continue
+				}
+				visited[real] = true
 			}
+			if err := ws.walkForDockerfiles(entryPath, depth+1, opts, matcher, visited, infos); err != nil {
+				return err
+			}
+			continue
 		}
+		if entry.Name() != dockerfileName {
+			continue
+		}
+		info, err := ws.parseDockerfile(entryPath)
+		if err != nil {
+			return err
+		}
+		info.Path = relPath
+		*infos = append(*infos, info)
+	}
+	return nil
+}
+
+// parseDockerfile extracts the base images and final stage name from a Dockerfile using a
+// lightweight line-based scan of its FROM instructions; it doesn't evaluate build args or
+// otherwise understand the rest of the Dockerfile grammar. FROM flags such as "--platform=..."
+// are skipped so they aren't mistaken for the base image.
+func (ws *Workspace) parseDockerfile(path string) (DockerfileInfo, error) {
+	data, err := ws.fsUtils.ReadFile(path)
+	if err != nil {
+		return DockerfileInfo{}, fmt.Errorf("read dockerfile %s: %w", path, err)
 	}
-	if len(directories) == 0 {
-		return nil, &ErrDockerfileNotFound{
-			dir: ws.workingDir,
+	var info DockerfileInfo
+	for _, line := range strings.Split(string(data), "\n") {
+		fields := strings.Fields(line)
+		if len(fields) < 2 || !strings.EqualFold(fields[0], "FROM") {
+			continue
+		}
+		args := fields[1:]
+		for len(args) > 0 && strings.HasPrefix(args[0], "--") {
+			args = args[1:]
+		}
+		if len(args) == 0 {
+			continue
+		}
+		info.BaseImages = append(info.BaseImages, args[0])
+		info.Stage = "" // Only the last FROM's stage name is kept.
+		if len(args) >= 3 && strings.EqualFold(args[1], "AS") {
+			info.Stage = args[2]
 		}
 	}
-	sort.Strings(directories)
-	dockerfiles := make([]string, 0, len(directories))
-	for _, dir := range directories {
-		file := dir + "/" + dockerfileName
-		dockerfiles = append(dockerfiles, file)
+	return info, nil
+}
+
+// dockerfileIgnoreMatcher builds a gitignore.Matcher from the contents of node_modules/, vendor/,
+// and .git/ (ignored by default), the working directory's .gitignore and .dockerignore files, and
+// any extra patterns, in that order. go-git's matcher applies last-match-wins precedence, so a later
+// "!pattern" correctly re-includes a path excluded by an earlier, broader pattern - including the
+// default directories, unlike a naive first-match loop or a hardcoded name check applied outside the
+// matcher.
+func (ws *Workspace) dockerfileIgnoreMatcher(extra []string) (gitignore.Matcher, error) {
+	var lines []string
+	for _, d := range defaultIgnoredDockerfileDirs {
+		lines = append(lines, d+"/**")
+	}
+	for _, name := range []string{".gitignore", ".dockerignore"} {
+		data, err := ws.fsUtils.ReadFile(filepath.Join(ws.workingDir, name))
+		if err != nil {
+			continue // No ignore file of this kind; nothing to add.
+		}
+		lines = append(lines, strings.Split(string(data), "\n")...)
+	}
+	lines = append(lines, extra...)
+
+	var patterns []gitignore.Pattern
+	for _, line := range lines {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		patterns = append(patterns, gitignore.ParsePattern(line, nil))
 	}
-	return dockerfiles, nil
+	return gitignore.NewMatcher(patterns), nil
 }
 
-// ErrDockerfileNotFound is returned when no Dockerfiles could be found in the current
-// working directory or in any directories one level down from it.
+// ErrDockerfileNotFound is returned when no Dockerfiles could be found within the configured search
+// depth below the working directory.
 type ErrDockerfileNotFound struct {
 	dir string
 }
 
 func (e *ErrDockerfileNotFound) Error() string {
-	return fmt.Sprintf("no Dockerfiles found within %s or a sub-directory level below", e.dir)
+	return fmt.Sprintf("no Dockerfiles found within %s or its configured sub-directories", e.dir)
+}
+
+// ErrK8sManifestNotFound is returned when a workload directory contains neither a
+// kustomization.yaml nor a Chart.yaml.
+type ErrK8sManifestNotFound struct {
+	workloadName string
+}
+
+func (e *ErrK8sManifestNotFound) Error() string {
+	return fmt.Sprintf("no kustomization.yaml or Chart.yaml found for workload %s", e.workloadName)
+}
+
+// ErrApplicationNotFound is returned when UseApplication is called with a name that hasn't been
+// registered via AddApplication.
+type ErrApplicationNotFound struct {
+	appName string
+}
+
+func (e *ErrApplicationNotFound) Error() string {
+	return fmt.Sprintf("application %s is not registered in this workspace", e.appName)
 }